@@ -0,0 +1,67 @@
+package resource
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var _ FullResource = (*testResource)(nil)
+
+// readOnlyResource implements only Indexer and Shower.
+type readOnlyResource struct{}
+
+func (readOnlyResource) Index(w http.ResponseWriter, r *http.Request) { testHandler(w, r) }
+func (readOnlyResource) Show(w http.ResponseWriter, r *http.Request)  { testHandler(w, r) }
+
+// patchableResource implements Shower and Patcher.
+type patchableResource struct{}
+
+func (patchableResource) Show(w http.ResponseWriter, r *http.Request)  { testHandler(w, r) }
+func (patchableResource) Patch(w http.ResponseWriter, r *http.Request) { testHandler(w, r) }
+
+func TestReadOnlyResource(t *testing.T) {
+	router := NewRouter()
+	router.HandleResource("/posts", readOnlyResource{})
+
+	testcases := []testCase{
+		{Path: "/posts", Method: http.MethodGet, Status: http.StatusOK, Matches: map[string]string{}},
+		{Path: "/posts/1", Method: http.MethodGet, Status: http.StatusOK, Matches: map[string]string{"id": "1"}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.String(), func(t *testing.T) {
+			tc := tc
+			tc.Run(t, router)
+		})
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/posts", nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST /posts status = %d, expected %d", w.Code, http.StatusMethodNotAllowed)
+	}
+	if allow := w.Header().Get("Allow"); allow != "GET" {
+		t.Errorf("Allow = %q, expected %q", allow, "GET")
+	}
+}
+
+func TestPatcherCapability(t *testing.T) {
+	router := NewRouter()
+	router.HandleResource("/posts", patchableResource{})
+
+	tc := testCase{
+		Path:    "/posts/1",
+		Method:  http.MethodPatch,
+		Status:  http.StatusOK,
+		Matches: map[string]string{"id": "1"},
+	}
+	tc.Run(t, router)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/posts/1", nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("DELETE /posts/1 status = %d, expected %d", w.Code, http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,222 @@
+package resource
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// A Codec encodes and decodes values for a particular MIME type.
+type Codec interface {
+	Decode(r io.Reader, v interface{}) error
+	Encode(w io.Writer, v interface{}) error
+}
+
+// defaultMIME is the Codec used when a request has no Content-Type or
+// Accept header, or names a type with no registered Codec.
+const defaultMIME = "application/json"
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = map[string]Codec{
+		"application/json":                  jsonCodec{},
+		"application/xml":                   xmlCodec{},
+		"text/xml":                          xmlCodec{},
+		"application/x-www-form-urlencoded": formCodec{},
+	}
+)
+
+// RegisterCodec registers c as the Codec used for mimeType, replacing any
+// Codec previously registered for it. Built-in codecs are registered for
+// "application/json", "application/xml" (and "text/xml"), and
+// "application/x-www-form-urlencoded"; callers can register additional
+// ones (e.g. msgpack) the same way.
+func RegisterCodec(mimeType string, c Codec) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	codecs[mimeType] = c
+}
+
+func codecFor(mimeType string) (Codec, bool) {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	c, ok := codecs[mimeType]
+	return c, ok
+}
+
+// Read decodes the request body into v using the Codec registered for the
+// request's Content-Type, falling back to JSON if the header is absent,
+// unparsable, or names a type with no registered Codec.
+func Read(r *http.Request, v interface{}) error {
+	mimeType := defaultMIME
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		if parsed, _, err := mime.ParseMediaType(ct); err == nil {
+			mimeType = parsed
+		}
+	}
+
+	c, ok := codecFor(mimeType)
+	if !ok {
+		c, _ = codecFor(defaultMIME)
+	}
+	return c.Decode(r.Body, v)
+}
+
+// Write encodes v to w using the Codec selected by negotiating the
+// request's Accept header, falling back to JSON if the header is absent
+// or names no registered Codec. It sets the response's Content-Type to
+// the negotiated MIME type.
+func Write(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	mimeType, c := negotiate(r.Header.Get("Accept"))
+	w.Header().Set("Content-Type", mimeType)
+	return c.Encode(w, v)
+}
+
+// Render writes status and v to w in one call, as Write does, and sets
+// Vary: Accept since the response body depends on the request's Accept
+// header.
+func Render(w http.ResponseWriter, r *http.Request, status int, v interface{}) error {
+	mimeType, c := negotiate(r.Header.Get("Accept"))
+	w.Header().Set("Content-Type", mimeType)
+	w.Header().Set("Vary", "Accept")
+	w.WriteHeader(status)
+	return c.Encode(w, v)
+}
+
+// negotiate picks the registered Codec that best matches accept, an HTTP
+// Accept header value, preferring higher q-values and falling back to
+// JSON if accept is empty, "*/*", or names no registered Codec.
+func negotiate(accept string) (string, Codec) {
+	if accept != "" {
+		for _, e := range parseAccept(accept) {
+			if e.mimeType == "*/*" {
+				break
+			}
+			if c, ok := codecFor(e.mimeType); ok {
+				return e.mimeType, c
+			}
+		}
+	}
+	c, _ := codecFor(defaultMIME)
+	return defaultMIME, c
+}
+
+// acceptEntry is one parsed element of an Accept header.
+type acceptEntry struct {
+	mimeType string
+	q        float64
+}
+
+// parseAccept parses an Accept header into its entries, sorted by
+// descending q-value (default 1.0).
+func parseAccept(header string) []acceptEntry {
+	parts := strings.Split(header, ",")
+	entries := make([]acceptEntry, 0, len(parts))
+
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		mimeType := part
+		q := 1.0
+		if i := strings.Index(part, ";"); i >= 0 {
+			mimeType = strings.TrimSpace(part[:i])
+			for _, param := range strings.Split(part[i+1:], ";") {
+				param = strings.TrimSpace(param)
+				if v, ok := cutPrefix(param, "q="); ok {
+					if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+						q = parsed
+					}
+				}
+			}
+		}
+
+		entries = append(entries, acceptEntry{mimeType: mimeType, q: q})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].q > entries[j].q })
+	return entries
+}
+
+func cutPrefix(s, prefix string) (string, bool) {
+	if !strings.HasPrefix(s, prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
+
+// jsonCodec is the built-in Codec for application/json.
+type jsonCodec struct{}
+
+func (jsonCodec) Decode(r io.Reader, v interface{}) error {
+	return json.NewDecoder(r).Decode(v)
+}
+
+func (jsonCodec) Encode(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// xmlCodec is the built-in Codec for application/xml and text/xml.
+type xmlCodec struct{}
+
+func (xmlCodec) Decode(r io.Reader, v interface{}) error {
+	return xml.NewDecoder(r).Decode(v)
+}
+
+func (xmlCodec) Encode(w io.Writer, v interface{}) error {
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return enc.Encode(v)
+}
+
+// formCodec is the built-in Codec for application/x-www-form-urlencoded.
+// It only knows how to decode into a *url.Values and encode a url.Values,
+// since the package has no struct-tag binding convention to decode into
+// arbitrary types.
+type formCodec struct{}
+
+func (formCodec) Decode(r io.Reader, v interface{}) error {
+	values, ok := v.(*url.Values)
+	if !ok {
+		return fmt.Errorf("resource: form codec requires *url.Values, got %T", v)
+	}
+
+	body, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	parsed, err := url.ParseQuery(string(body))
+	if err != nil {
+		return err
+	}
+
+	*values = parsed
+	return nil
+}
+
+func (formCodec) Encode(w io.Writer, v interface{}) error {
+	switch values := v.(type) {
+	case url.Values:
+		_, err := io.WriteString(w, values.Encode())
+		return err
+	case *url.Values:
+		_, err := io.WriteString(w, values.Encode())
+		return err
+	default:
+		return fmt.Errorf("resource: form codec requires url.Values, got %T", v)
+	}
+}
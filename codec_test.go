@@ -0,0 +1,103 @@
+package resource
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type codecPayload struct {
+	Name string `json:"name" xml:"name"`
+}
+
+func TestReadWriteJSON(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"ada"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	var p codecPayload
+	if err := Read(req, &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "ada" {
+		t.Errorf("Name = %q, expected %q", p.Name, "ada")
+	}
+
+	w := httptest.NewRecorder()
+	req.Header.Set("Accept", "application/json")
+	if err := Write(w, req, p); err != nil {
+		t.Fatal(err)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, expected %q", ct, "application/json")
+	}
+
+	var out codecPayload
+	if err := json.Unmarshal(w.Body.Bytes(), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Name != "ada" {
+		t.Errorf("Name = %q, expected %q", out.Name, "ada")
+	}
+}
+
+func TestWriteXML(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept", "application/xml, application/json;q=0.5")
+
+	w := httptest.NewRecorder()
+	if err := Write(w, req, codecPayload{Name: "ada"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/xml" {
+		t.Errorf("Content-Type = %q, expected %q", ct, "application/xml")
+	}
+	if !strings.Contains(w.Body.String(), "<name>ada</name>") {
+		t.Errorf("body = %q, expected it to contain <name>ada</name>", w.Body.String())
+	}
+}
+
+func TestWriteFallsBackToJSON(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept", "application/msgpack")
+
+	w := httptest.NewRecorder()
+	if err := Write(w, req, codecPayload{Name: "ada"}); err != nil {
+		t.Fatal(err)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, expected %q", ct, "application/json")
+	}
+}
+
+func TestRender(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	w := httptest.NewRecorder()
+	if err := Render(w, req, http.StatusCreated, codecPayload{Name: "ada"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if w.Code != http.StatusCreated {
+		t.Errorf("status = %d, expected %d", w.Code, http.StatusCreated)
+	}
+	if vary := w.Header().Get("Vary"); vary != "Accept" {
+		t.Errorf("Vary = %q, expected %q", vary, "Accept")
+	}
+}
+
+func TestFormCodec(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("name=ada"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var values url.Values
+	if err := Read(req, &values); err != nil {
+		t.Fatal(err)
+	}
+	if got := values.Get("name"); got != "ada" {
+		t.Errorf("name = %q, expected %q", got, "ada")
+	}
+}
@@ -0,0 +1,49 @@
+package resource
+
+import (
+	"net/http"
+	"path"
+)
+
+// Method registers h for method at pattern. Unlike Handle, registrations
+// for the same pattern coexist across methods and dispatch by method;
+// requesting a method not registered for a matched pattern gets a 405
+// with an Allow header listing the methods that are.
+func (router *Router) Method(method, pattern string, h http.HandlerFunc) {
+	router.tree.insert(method, path.Join(router.prefix, pattern), router.wrap(h))
+}
+
+// GET registers h for GET requests matching pattern.
+func (router *Router) GET(pattern string, h http.HandlerFunc) {
+	router.Method(http.MethodGet, pattern, h)
+}
+
+// POST registers h for POST requests matching pattern.
+func (router *Router) POST(pattern string, h http.HandlerFunc) {
+	router.Method(http.MethodPost, pattern, h)
+}
+
+// PUT registers h for PUT requests matching pattern.
+func (router *Router) PUT(pattern string, h http.HandlerFunc) {
+	router.Method(http.MethodPut, pattern, h)
+}
+
+// PATCH registers h for PATCH requests matching pattern.
+func (router *Router) PATCH(pattern string, h http.HandlerFunc) {
+	router.Method(http.MethodPatch, pattern, h)
+}
+
+// DELETE registers h for DELETE requests matching pattern.
+func (router *Router) DELETE(pattern string, h http.HandlerFunc) {
+	router.Method(http.MethodDelete, pattern, h)
+}
+
+// HEAD registers h for HEAD requests matching pattern.
+func (router *Router) HEAD(pattern string, h http.HandlerFunc) {
+	router.Method(http.MethodHead, pattern, h)
+}
+
+// OPTIONS registers h for OPTIONS requests matching pattern.
+func (router *Router) OPTIONS(pattern string, h http.HandlerFunc) {
+	router.Method(http.MethodOptions, pattern, h)
+}
@@ -0,0 +1,72 @@
+package resource
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRouterMethods(t *testing.T) {
+	router := NewRouter()
+	router.GET("/posts/:id", testHandler)
+	router.PATCH("/posts/:id", testHandler)
+
+	testcases := []testCase{
+		{
+			Path:    "/posts/1",
+			Method:  http.MethodGet,
+			Status:  http.StatusOK,
+			Matches: map[string]string{"id": "1"},
+		},
+		{
+			Path:    "/posts/1",
+			Method:  http.MethodPatch,
+			Status:  http.StatusOK,
+			Matches: map[string]string{"id": "1"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.String(), func(t *testing.T) {
+			tc := tc
+			tc.Run(t, router)
+		})
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/posts/1", nil)
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("status = %d, expected %d", w.Code, http.StatusMethodNotAllowed)
+	}
+
+	if allow := w.Header().Get("Allow"); allow != "GET, PATCH" {
+		t.Errorf("Allow = %q, expected %q", allow, "GET, PATCH")
+	}
+}
+
+func TestRouterErrorHandlers(t *testing.T) {
+	router := NewRouter()
+	router.GET("/posts/:id", testHandler)
+	router.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "custom not found", http.StatusNotFound)
+	})
+	router.MethodNotAllowedHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "custom not allowed", http.StatusMethodNotAllowed)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/comments/1", nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound || w.Body.String() != "custom not found\n" {
+		t.Errorf("got %d %q, expected custom not found handler", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodDelete, "/posts/1", nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusMethodNotAllowed || w.Body.String() != "custom not allowed\n" {
+		t.Errorf("got %d %q, expected custom not allowed handler", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,21 @@
+package resource
+
+import "net/http"
+
+// Middleware wraps an http.Handler to add behaviour such as logging,
+// authentication, or panic recovery. Middleware registered on a Router
+// applies to every handler and resource registered on it after the Use
+// call; middleware
+// registered on a Group applies only to routes registered through that
+// Group; middleware registered on a resource wraps only that resource's
+// handlers, after route matching, so FromContext is available inside it.
+type Middleware func(http.Handler) http.Handler
+
+// chain wraps h with mws in order, so that mws[0] is outermost and runs
+// first.
+func chain(mws []Middleware, h http.Handler) http.Handler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
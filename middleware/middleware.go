@@ -0,0 +1,97 @@
+// Package middleware provides a few resource.Middleware implementations
+// commonly needed by HTTP services: request logging, panic recovery, and
+// CORS.
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/ascarter/resource"
+)
+
+// Logger is a resource.Middleware that logs each request's method, path,
+// status code, and duration.
+func Logger(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, r)
+		log.Printf("%s %s %d %s", r.Method, r.URL.Path, sw.status, time.Since(start))
+	})
+}
+
+// statusWriter captures the status code passed to WriteHeader so it can be
+// logged after the handler has run.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (sw *statusWriter) WriteHeader(status int) {
+	sw.status = status
+	sw.ResponseWriter.WriteHeader(status)
+}
+
+// Recovery is a resource.Middleware that recovers from a panic in next
+// and responds with a 500 and a JSON body describing the error,
+// instead of letting net/http close the connection.
+func Recovery(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if err := recover(); err != nil {
+				// Marshal before WriteHeader: resource.WriteJSON sets
+				// Content-Type itself, and that has no effect once the
+				// status has already been written.
+				output, marshalErr := json.MarshalIndent(map[string]string{
+					"error": fmt.Sprintf("%v", err),
+				}, "", "  ")
+				if marshalErr != nil {
+					http.Error(w, marshalErr.Error(), http.StatusInternalServerError)
+					return
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write(output)
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// CORS returns a resource.Middleware that sets CORS headers for requests
+// whose Origin is in allowedOrigins (or for all origins, if allowedOrigins
+// contains "*") and answers preflight OPTIONS requests directly, in the
+// spirit of gorilla/handlers' CORS.
+func CORS(allowedOrigins ...string) resource.Middleware {
+	allowed := make(map[string]bool, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		allowed[origin] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin != "" && (allowed["*"] || allowed[origin]) {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+				w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+				w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+			}
+
+			// A request is only a CORS preflight if it's an OPTIONS with
+			// both Origin and Access-Control-Request-Method set; anything
+			// else, including a plain OPTIONS request to a real handler,
+			// falls through to next.
+			if r.Method == http.MethodOptions && origin != "" && r.Header.Get("Access-Control-Request-Method") != "" {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
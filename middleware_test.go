@@ -0,0 +1,78 @@
+package resource
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func markerMiddleware(name string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("X-Middleware", name)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func TestRouterMiddleware(t *testing.T) {
+	router := NewRouter()
+	router.Use(markerMiddleware("one"), markerMiddleware("two"))
+	router.HandleFunc("/ping", testHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/ping", nil)
+	router.ServeHTTP(w, req)
+
+	got := w.Header()["X-Middleware"]
+	expected := []string{"one", "two"}
+	if len(got) != len(expected) || got[0] != expected[0] || got[1] != expected[1] {
+		t.Errorf("X-Middleware = %v, expected %v", got, expected)
+	}
+}
+
+func TestGroupMiddleware(t *testing.T) {
+	router := NewRouter()
+	router.Use(markerMiddleware("root"))
+
+	api := router.Group("/api")
+	api.Use(markerMiddleware("api"))
+	api.HandleFunc("/ping", testHandler)
+
+	router.HandleFunc("/ping", testHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/ping", nil)
+	router.ServeHTTP(w, req)
+
+	got := w.Header()["X-Middleware"]
+	expected := []string{"root", "api"}
+	if len(got) != len(expected) || got[0] != expected[0] || got[1] != expected[1] {
+		t.Errorf("X-Middleware = %v, expected %v", got, expected)
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/ping", nil)
+	router.ServeHTTP(w, req)
+
+	got = w.Header()["X-Middleware"]
+	if len(got) != 1 || got[0] != "root" {
+		t.Errorf("X-Middleware = %v, expected [root]", got)
+	}
+}
+
+func TestHandleResourceMiddleware(t *testing.T) {
+	router := NewRouter()
+	router.Use(markerMiddleware("root"))
+	router.HandleResource("/posts", &testResource{}, markerMiddleware("posts"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/posts/1", nil)
+	router.ServeHTTP(w, req)
+
+	got := w.Header()["X-Middleware"]
+	expected := []string{"root", "posts"}
+	if len(got) != len(expected) || got[0] != expected[0] || got[1] != expected[1] {
+		t.Errorf("X-Middleware = %v, expected %v", got, expected)
+	}
+}
@@ -0,0 +1,54 @@
+package resource
+
+import (
+	"net/http"
+	"path"
+	"strings"
+)
+
+// A ResourceRoute is a resource mounted on a Router, returned by
+// Router.Resource so that further resources can be nested beneath it.
+type ResourceRoute struct {
+	router  *Router
+	prefix  string
+	idParam string
+}
+
+// Resource mounts resource's routes under prefix, as HandleResource does,
+// and returns a ResourceRoute so a child resource can be nested beneath
+// it:
+//
+//	router.Resource("/posts", &PostResource{}).Resource("/comments", &CommentResource{})
+//
+// registers comments at /posts/:post_id/comments and
+// /posts/:post_id/comments/:id.
+func (router *Router) Resource(prefix string, resource Resource, mw ...Middleware) *ResourceRoute {
+	p := trimPath(path.Join(router.prefix, prefix))
+	wrap := func(h http.Handler) http.Handler { return router.wrap(h, mw...) }
+	mountResourceWith(router.tree, p, resource, wrap)
+
+	return &ResourceRoute{router: router, prefix: p, idParam: singularID(prefix)}
+}
+
+// Resource mounts a child resource's routes beneath rr, under rr's own
+// :id segment renamed to rr's singular id param (e.g. :post_id), and
+// returns the child's ResourceRoute for further nesting.
+func (rr *ResourceRoute) Resource(prefix string, resource Resource, mw ...Middleware) *ResourceRoute {
+	p := trimPath(path.Join(rr.prefix, ":"+rr.idParam, prefix))
+	wrap := func(h http.Handler) http.Handler { return rr.router.wrap(h, mw...) }
+	mountResourceWith(rr.router.tree, p, resource, wrap)
+
+	return &ResourceRoute{router: rr.router, prefix: p, idParam: singularID(prefix)}
+}
+
+// singularID derives the :id param name a resource mounted at prefix
+// exposes to a nested child, by taking prefix's last path segment,
+// dropping a trailing "s", and appending "_id" (posts -> post_id).
+func singularID(prefix string) string {
+	name := trimPath(prefix)
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.TrimSuffix(name, "s")
+	return name + "_id"
+}
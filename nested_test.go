@@ -0,0 +1,50 @@
+package resource
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNestedResource(t *testing.T) {
+	router := NewRouter()
+	router.Resource("/posts", &testResource{}).Resource("/comments", &testResource{})
+
+	testcases := []testCase{
+		{
+			Path:    "/posts/1/comments",
+			Method:  http.MethodGet,
+			Status:  http.StatusOK,
+			Matches: map[string]string{"id": "1", "post_id": "1"},
+		},
+		{
+			Path:    "/posts/1/comments/2",
+			Method:  http.MethodGet,
+			Status:  http.StatusOK,
+			Matches: map[string]string{"id": "2", "post_id": "1"},
+		},
+		{
+			// The parent resource's own route must not pick up the
+			// post_id alias added for its nested comments resource: that
+			// alias only applies to requests that continue on into
+			// /posts/:post_id/comments..., not to /posts/:id itself.
+			Path:    "/posts/1",
+			Method:  http.MethodGet,
+			Status:  http.StatusOK,
+			Matches: map[string]string{"id": "1"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.String(), func(t *testing.T) {
+			tc := tc
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest(tc.Method, tc.Path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			router.ServeHTTP(w, req)
+			tc.Verify(t, w)
+		})
+	}
+}
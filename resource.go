@@ -2,11 +2,7 @@ package resource
 
 import (
 	"context"
-	"encoding/json"
-	"io/ioutil"
 	"net/http"
-	"path"
-	"strings"
 )
 
 type ctxKey int
@@ -17,97 +13,138 @@ const paramsKey ctxKey = ctxKey(0)
 // Param ID's are expected to be unique.
 type RouteParams map[string]string
 
-// NewContext creates a context with matched request params
-func NewContext(ctx context.Context, r *http.Request, pattern string) context.Context {
-	urlParts := strings.Split(r.URL.Path, "/")
-	patParts := strings.Split(pattern, "/")
-
-	params := RouteParams{}
-	for i, p := range patParts {
-		if len(urlParts) <= i {
-			break
-		}
-		u := urlParts[i]
-		if len(p) > 0 && p[0] == ':' {
-			params[p[1:]] = u
-		}
-	}
-
-	return context.WithValue(ctx, paramsKey, params)
-}
-
 // FromContext returns the matched params from context
 func FromContext(ctx context.Context) (RouteParams, bool) {
 	params, ok := ctx.Value(paramsKey).(RouteParams)
 	return params, ok
 }
 
-// ReadJSON reads data from request body to the interface provided.
+// ReadJSON reads data from request body to the interface provided. It's a
+// thin wrapper around jsonCodec, kept for code written before Read/Write
+// introduced content negotiation.
 func ReadJSON(r *http.Request, data interface{}) error {
-	body, err := ioutil.ReadAll(r.Body)
-	if err != nil {
-		return err
-	}
-
-	if err := json.Unmarshal(body, data); err != nil {
-		return err
-	}
-
-	return nil
+	return jsonCodec{}.Decode(r.Body, data)
 }
 
 // WriteJSON writes data as JSON to the output writer.
-// Data expected to be able to be marshaled to JSON.
+// Data expected to be able to be marshaled to JSON. It's a thin wrapper
+// around jsonCodec, kept for code written before Read/Write introduced
+// content negotiation.
 func WriteJSON(w http.ResponseWriter, data interface{}) error {
-	output, err := json.MarshalIndent(data, "", "  ")
-	if err != nil {
-		return err
-	}
 	w.Header().Set("Content-Type", "application/json")
-	w.Write(output)
-	return nil
+	return jsonCodec{}.Encode(w, data)
 }
 
-// A Resource implements handlers for REST routes.
-//
-// Resources map specific HTTP methods to route patterns. Each method in the
-// interface performs a specific operation on the resource. Each action generally
-// corresponds to a CRUD operation typically in a database.
-//
-// For a `photos` resource:
-//	Method     HTTP Method     Path            Used For
-//	------     -----------     -----------     --------------------------
-//	Index      GET             /photos         display list of all photos
-//	Create     POST            /photos         create a new photo
-//	Show       GET             /photos/:id     display specific photo
-//	Update     PUT             /photos/:id     update a specific photo
-//	Destroy    DELETE          /photos/:id     delete a specific photo
-type Resource interface {
+// Indexer handles GET requests for a resource's collection path, e.g.
+// GET /photos.
+type Indexer interface {
 	Index(http.ResponseWriter, *http.Request)
+}
+
+// Creator handles POST requests for a resource's collection path, e.g.
+// POST /photos.
+type Creator interface {
 	Create(http.ResponseWriter, *http.Request)
+}
+
+// Shower handles GET requests for a resource's member path, e.g.
+// GET /photos/:id.
+type Shower interface {
 	Show(http.ResponseWriter, *http.Request)
+}
+
+// Updater handles PUT requests for a resource's member path, e.g.
+// PUT /photos/:id.
+type Updater interface {
 	Update(http.ResponseWriter, *http.Request)
+}
+
+// Patcher handles PATCH requests for a resource's member path, e.g.
+// PATCH /photos/:id.
+type Patcher interface {
+	Patch(http.ResponseWriter, *http.Request)
+}
+
+// Destroyer handles DELETE requests for a resource's member path, e.g.
+// DELETE /photos/:id.
+type Destroyer interface {
 	Destroy(http.ResponseWriter, *http.Request)
 }
 
+// A Resource maps HTTP methods to route patterns:
+//
+//	Method     HTTP Method     Path            Capability
+//	------     -----------     -----------     --------------
+//	Index      GET             /photos         Indexer
+//	Create     POST            /photos         Creator
+//	Show       GET             /photos/:id     Shower
+//	Update     PUT             /photos/:id     Updater
+//	Patch      PATCH           /photos/:id     Patcher
+//	Destroy    DELETE          /photos/:id     Destroyer
+//
+// A Resource is not required to implement all of these; mountResourceWith
+// registers a route only for the capability interfaces a value actually
+// implements, so e.g. a read-only resource can implement just Indexer and
+// Shower. Requesting a method the resource doesn't implement 405s like any
+// other unregistered method on a matched path.
+type Resource interface{}
+
+// FullResource is the Resource interface prior to the introduction of
+// capability interfaces, forcing every CRUD method. It's kept so existing
+// code that depended on Resource requiring all five methods can migrate
+// by using FullResource instead.
+type FullResource interface {
+	Indexer
+	Creator
+	Shower
+	Updater
+	Destroyer
+}
+
 // trimPath drops trailing `/`
 func trimPath(p string) string {
 	n := len(p)
-	if p[n-1] == '/' {
+	if n > 0 && p[n-1] == '/' {
 		p = p[:n-1]
 	}
 	return p
 }
 
-// NewResourceHandler returns a new resource handler instance.
-func NewResourceHandler(prefix string, resource Resource) http.Handler {
-	n := len(prefix)
-	if prefix[n-1] == '/' {
-		// Drop trailing `/`
-		prefix = prefix[:n-1]
+// mountResource registers a Resource's routes onto t rooted at prefix.
+func mountResource(t *tree, prefix string, resource Resource) {
+	mountResourceWith(t, prefix, resource, func(h http.Handler) http.Handler { return h })
+}
+
+// mountResourceWith registers a route for each capability interface
+// resource implements onto t rooted at prefix, passing each handler
+// through wrap before it is inserted.
+func mountResourceWith(t *tree, prefix string, resource Resource, wrap func(http.Handler) http.Handler) {
+	if r, ok := resource.(Indexer); ok {
+		t.insert(http.MethodGet, prefix, wrap(http.HandlerFunc(r.Index)))
+	}
+	if r, ok := resource.(Creator); ok {
+		t.insert(http.MethodPost, prefix, wrap(http.HandlerFunc(r.Create)))
+	}
+	if r, ok := resource.(Shower); ok {
+		t.insert(http.MethodGet, prefix+"/:id", wrap(http.HandlerFunc(r.Show)))
+	}
+	if r, ok := resource.(Updater); ok {
+		t.insert(http.MethodPut, prefix+"/:id", wrap(http.HandlerFunc(r.Update)))
 	}
+	if r, ok := resource.(Patcher); ok {
+		t.insert(http.MethodPatch, prefix+"/:id", wrap(http.HandlerFunc(r.Patch)))
+	}
+	if r, ok := resource.(Destroyer); ok {
+		t.insert(http.MethodDelete, prefix+"/:id", wrap(http.HandlerFunc(r.Destroy)))
+	}
+}
 
-	return &resourceHandler{resource: resource, prefix: trimPath(prefix)}
+// NewResourceHandler returns a new resource handler instance.
+func NewResourceHandler(prefix string, resource Resource) http.Handler {
+	prefix = trimPath(prefix)
+	t := newTree()
+	mountResource(t, prefix, resource)
+	return &resourceHandler{resource: resource, prefix: prefix, tree: t}
 }
 
 // A resourceHandler routes requests to a Resource.
@@ -115,71 +152,26 @@ func NewResourceHandler(prefix string, resource Resource) http.Handler {
 type resourceHandler struct {
 	resource Resource
 	prefix   string
+	tree     *tree
 }
 
 // ServeHTTP dispatches request to Resource
 func (h *resourceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	p := trimPath(r.URL.Path)
+	p := CleanPath(r.URL.Path)
 
-	// Verify resource prefix
-	if !strings.HasPrefix(p, h.prefix) {
+	n, params := h.tree.match(p)
+	if n == nil || !n.registered() {
 		http.NotFound(w, r)
 		return
 	}
 
-	switch r.Method {
-	default:
+	handler, ok := n.handler(r.Method)
+	if !ok {
+		w.Header().Set("Allow", n.allow())
 		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
-	case http.MethodGet:
-		s := strings.TrimPrefix(p, h.prefix)
-		if len(s) > 0 {
-			ctx := NewContext(r.Context(), r, path.Join(h.prefix, ":id"))
-			h.resource.Show(w, r.WithContext(ctx))
-		} else {
-			h.resource.Index(w, r)
-		}
-	case http.MethodPost:
-		h.resource.Create(w, r)
-	case http.MethodPut:
-		ctx := NewContext(r.Context(), r, path.Join(h.prefix, ":id"))
-		h.resource.Update(w, r.WithContext(ctx))
-	case http.MethodDelete:
-		ctx := NewContext(r.Context(), r, path.Join(h.prefix, ":id"))
-		h.resource.Destroy(w, r.WithContext(ctx))
+		return
 	}
 
-	return
-}
-
-// A Router dispatches resource paths to resources.
-// Router is compatible with http.ServeMux and can be used as a drop-in replacement.
-type Router struct {
-	mux *http.ServeMux
-}
-
-// NewRouter returns a new Router instance.
-func NewRouter() *Router {
-	return &Router{mux: http.NewServeMux()}
-}
-
-// Handle registers a handler for a pattern.
-func (router *Router) Handle(pattern string, handler http.Handler) {
-	router.mux.Handle(pattern, handler)
-}
-
-// HandleFunc registers a handler function for a pattern.
-func (router *Router) HandleFunc(pattern string, fn http.HandlerFunc) {
-	router.mux.HandleFunc(pattern, fn)
-}
-
-// HandleResource registers a resource as a handler for a pattern.
-func (router *Router) HandleResource(pattern string, resource Resource) {
-	p := trimPath(pattern)
-	h := NewResourceHandler(p, resource)
-	router.mux.Handle(p, h)
-	router.mux.Handle(p+"/", h)
-}
-
-func (router *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	router.mux.ServeHTTP(w, r)
+	ctx := context.WithValue(r.Context(), paramsKey, params)
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
@@ -205,6 +205,39 @@ func TestRouter(t *testing.T) {
 	}
 }
 
+func TestRouterRedirectCleanPath(t *testing.T) {
+	router := NewRouter()
+	router.RedirectCleanPath = true
+	router.HandleFunc("/posts", testHandler)
+
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	res, err := client.Get(server.URL + "/posts/./1/..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusMovedPermanently {
+		t.Fatalf("status = %d, expected %d", res.StatusCode, http.StatusMovedPermanently)
+	}
+
+	loc, err := res.Location()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loc.Path != "/posts" {
+		t.Errorf("Location = %q, expected %q", loc.Path, "/posts")
+	}
+}
+
 func TestResourceRouter(t *testing.T) {
 	testcases := []testCase{
 		{
@@ -245,16 +278,14 @@ func TestResourceRouter(t *testing.T) {
 			Matches: map[string]string{"id": "1"},
 		},
 		{
-			Path:    "/posts/23/comments",
-			Method:  http.MethodGet,
-			Status:  http.StatusOK,
-			Matches: map[string]string{"id": "23"},
+			Path:   "/posts/23/comments",
+			Method: http.MethodGet,
+			Status: http.StatusNotFound,
 		},
 		{
-			Path:    "/posts/23/foo",
-			Method:  http.MethodGet,
-			Status:  http.StatusOK,
-			Matches: map[string]string{"id": "23"},
+			Path:   "/posts/23/foo",
+			Method: http.MethodGet,
+			Status: http.StatusNotFound,
 		},
 		{
 			Path:   "/foo",
@@ -350,16 +381,14 @@ func TestResourceHandler(t *testing.T) {
 			Matches: map[string]string{"id": "1"},
 		},
 		{
-			Path:    "/posts/23/comments",
-			Method:  http.MethodGet,
-			Status:  http.StatusOK,
-			Matches: map[string]string{"id": "23"},
+			Path:   "/posts/23/comments",
+			Method: http.MethodGet,
+			Status: http.StatusNotFound,
 		},
 		{
-			Path:    "/posts/23/foo",
-			Method:  http.MethodGet,
-			Status:  http.StatusOK,
-			Matches: map[string]string{"id": "23"},
+			Path:   "/posts/23/foo",
+			Method: http.MethodGet,
+			Status: http.StatusNotFound,
 		},
 		{
 			Path:   "/foo",
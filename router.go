@@ -0,0 +1,143 @@
+package resource
+
+import (
+	"context"
+	"net/http"
+	"path"
+)
+
+// A Router dispatches requests to handlers and resources registered on a
+// radix tree of path segments. Routes may contain `:param` segments at any
+// position and a trailing `*wildcard` segment. Static segments always take
+// priority over params, and params over a wildcard.
+//
+// A Router may be split into Groups sharing the same tree; middleware
+// registered with Use applies to every route registered through that
+// Router or Group from that point on.
+type Router struct {
+	tree        *tree
+	prefix      string
+	middlewares []Middleware
+
+	// RedirectCleanPath, when true, causes requests whose path is not
+	// already in canonical form (see CleanPath) to be answered with a
+	// 301 redirect to the cleaned path instead of being matched as-is.
+	RedirectCleanPath bool
+
+	// NotFoundHandler, if set, is used to respond when no route matches
+	// the request path. The default is http.NotFound.
+	NotFoundHandler http.Handler
+
+	// MethodNotAllowedHandler, if set, is used to respond when a route
+	// matches the request path but not its method. The Allow header for
+	// the matched route is already set when it is called. The default
+	// responds with http.StatusMethodNotAllowed.
+	MethodNotAllowedHandler http.Handler
+}
+
+// NewRouter returns a new Router instance.
+func NewRouter() *Router {
+	return &Router{tree: newTree()}
+}
+
+// Use appends middleware to the chain applied to every handler and
+// resource registered on router from this point on, including through any
+// Group derived from it afterwards. A Group created before the call keeps
+// the middleware stack it copied when it was created.
+func (router *Router) Use(mw ...Middleware) {
+	router.middlewares = append(router.middlewares, mw...)
+}
+
+// Group returns a Router rooted at prefix, sharing router's tree and
+// inheriting its middleware stack. Routes registered through the group are
+// mounted at prefix and Use calls on the group do not affect router.
+func (router *Router) Group(prefix string) *Router {
+	middlewares := make([]Middleware, len(router.middlewares))
+	copy(middlewares, router.middlewares)
+
+	return &Router{
+		tree:              router.tree,
+		prefix:            path.Join(router.prefix, prefix),
+		middlewares:       middlewares,
+		RedirectCleanPath: router.RedirectCleanPath,
+	}
+}
+
+// wrap chains h with router's middleware stack followed by extra,
+// outermost first.
+func (router *Router) wrap(h http.Handler, extra ...Middleware) http.Handler {
+	mws := make([]Middleware, 0, len(router.middlewares)+len(extra))
+	mws = append(mws, router.middlewares...)
+	mws = append(mws, extra...)
+	return chain(mws, h)
+}
+
+// Handle registers a handler for a pattern. The handler is invoked
+// regardless of request method; FromContext is populated from any :param
+// or *wildcard segments in pattern.
+func (router *Router) Handle(pattern string, handler http.Handler) {
+	router.tree.insertAny(path.Join(router.prefix, pattern), router.wrap(handler))
+}
+
+// HandleFunc registers a handler function for a pattern.
+func (router *Router) HandleFunc(pattern string, fn http.HandlerFunc) {
+	router.Handle(pattern, fn)
+}
+
+// HandleResource registers a resource's routes under pattern:
+//
+//	GET     pattern           Index
+//	POST    pattern           Create
+//	GET     pattern/:id       Show
+//	PUT     pattern/:id       Update
+//	PATCH   pattern/:id       Patch
+//	DELETE  pattern/:id       Destroy
+//
+// Only the routes whose capability interface resource implements are
+// registered. mw, if given, wraps only this resource's handlers, applied after
+// router's own middleware stack.
+func (router *Router) HandleResource(pattern string, resource Resource, mw ...Middleware) {
+	prefix := trimPath(path.Join(router.prefix, pattern))
+	wrap := func(h http.Handler) http.Handler { return router.wrap(h, mw...) }
+	mountResourceWith(router.tree, prefix, resource, wrap)
+}
+
+// ServeHTTP dispatches r to the handler registered for its path and
+// method, responding 404 if no route matches the path and 405 (with an
+// Allow header) if a route matches the path but not the method.
+func (router *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p := r.URL.Path
+	if clean := CleanPath(p); clean != p {
+		if router.RedirectCleanPath {
+			u := *r.URL
+			u.Path = clean
+			http.Redirect(w, r, u.String(), http.StatusMovedPermanently)
+			return
+		}
+		p = clean
+	}
+
+	n, params := router.tree.match(p)
+	if n == nil || !n.registered() {
+		if router.NotFoundHandler != nil {
+			router.NotFoundHandler.ServeHTTP(w, r)
+			return
+		}
+		http.NotFound(w, r)
+		return
+	}
+
+	handler, ok := n.handler(r.Method)
+	if !ok {
+		w.Header().Set("Allow", n.allow())
+		if router.MethodNotAllowedHandler != nil {
+			router.MethodNotAllowedHandler.ServeHTTP(w, r)
+			return
+		}
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), paramsKey, params)
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
@@ -0,0 +1,259 @@
+package resource
+
+import (
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+)
+
+// nodeKind identifies what sort of path segment a routeNode was registered
+// for, which in turn determines matching priority: static segments are
+// tried before params, and params before a trailing wildcard.
+type nodeKind int
+
+const (
+	staticKind nodeKind = iota
+	paramKind
+	wildcardKind
+)
+
+// routeNode is one segment of a registered pattern in a tree. Each node may
+// carry method-specific handlers (routes), a method-agnostic handler (any),
+// and at most one param child and one wildcard child in addition to any
+// number of static children.
+type routeNode struct {
+	kind     nodeKind
+	segment  string // literal text for staticKind, param/wildcard name otherwise
+	children map[string]*routeNode
+	param    *routeNode
+	wildcard *routeNode
+	routes   map[string]http.Handler
+	any      http.Handler
+
+	// childAliases are additional param names by which this node's segment
+	// value is also exposed in RouteParams, scoped to the immediate next
+	// static segment of the path being matched (e.g. "comments"). A param
+	// position can only have one child node, so nesting a resource under
+	// another resource's :id (renamed to e.g. :post_id) adds an alias here
+	// rather than a second, conflicting param node. Keying by the next
+	// segment keeps the alias scoped to that nested subtree: a request
+	// that stops at this node instead of continuing into it (the parent
+	// resource's own route) doesn't pick it up.
+	childAliases map[string][]string
+}
+
+// tree is a radix-style tree of path segments used to dispatch requests to
+// handlers. Unlike http.ServeMux, it matches param (`:id`) segments
+// anywhere in a pattern and a wildcard (`*path`) segment at its end, and
+// distinguishes a path that
+// matches no route (404) from one that matches a route but not the request
+// method (405).
+type tree struct {
+	root *routeNode
+}
+
+func newTree() *tree {
+	return &tree{root: &routeNode{}}
+}
+
+// containsString reports whether ss contains s.
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// splitSegments splits a cleaned path into its non-empty segments.
+func splitSegments(p string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
+
+// insert registers h for method at pattern. A segment beginning with ':'
+// is a param segment; a segment beginning with '*' is a wildcard and must
+// be the last segment in pattern.
+func (t *tree) insert(method, pattern string, h http.Handler) {
+	n := t.descend(pattern)
+	if n.routes == nil {
+		n.routes = map[string]http.Handler{}
+	}
+	n.routes[method] = h
+}
+
+// insertAny registers h for pattern regardless of request method, as used
+// by Router.Handle/HandleFunc.
+func (t *tree) insertAny(pattern string, h http.Handler) {
+	n := t.descend(pattern)
+	n.any = h
+}
+
+// descend walks (creating as needed) the nodes for pattern and returns the
+// terminal node.
+func (t *tree) descend(pattern string) *routeNode {
+	segments := splitSegments(pattern)
+	n := t.root
+	for i, seg := range segments {
+		switch {
+		case strings.HasPrefix(seg, ":"):
+			name := seg[1:]
+			switch {
+			case n.param == nil:
+				n.param = &routeNode{kind: paramKind, segment: name}
+			case name != n.param.segment && i+1 < len(segments):
+				// name aliases the existing param for requests that
+				// continue on into segments[i+1] (a nested resource's
+				// routes), not for the parent's own route at this node.
+				key := segments[i+1]
+				if n.param.childAliases == nil {
+					n.param.childAliases = map[string][]string{}
+				}
+				if !containsString(n.param.childAliases[key], name) {
+					n.param.childAliases[key] = append(n.param.childAliases[key], name)
+				}
+			}
+			n = n.param
+		case strings.HasPrefix(seg, "*"):
+			name := seg[1:]
+			if n.wildcard == nil {
+				n.wildcard = &routeNode{kind: wildcardKind, segment: name}
+			}
+			n = n.wildcard
+		default:
+			if n.children == nil {
+				n.children = map[string]*routeNode{}
+			}
+			child, ok := n.children[seg]
+			if !ok {
+				child = &routeNode{kind: staticKind, segment: seg}
+				n.children[seg] = child
+			}
+			n = child
+		}
+	}
+	return n
+}
+
+// match walks path against the tree, preferring a static child over a
+// param child over a wildcard child at each level, and returns the node
+// reached, if any, and the params collected along the way. A branch is
+// only committed to once it leads to a registered node; if a static
+// child is a dead end (it, and everything under it, has no route of its
+// own), match backs up and retries the param or wildcard sibling at that
+// level instead of failing outright, the way httprouter/chi do.
+func (t *tree) match(p string) (*routeNode, RouteParams) {
+	n, params, ok := matchNode(t.root, splitSegments(p))
+	if !ok {
+		return nil, nil
+	}
+	return n, params
+}
+
+// matchNode tries to match segments starting at n, trying a static child
+// first, then a param child, then a wildcard child, backtracking to the
+// next option whenever a branch doesn't lead to a registered node.
+func matchNode(n *routeNode, segments []string) (*routeNode, RouteParams, bool) {
+	if len(segments) == 0 {
+		if n.registered() {
+			return n, RouteParams{}, true
+		}
+		return nil, nil, false
+	}
+
+	seg, rest := segments[0], segments[1:]
+
+	if n.children != nil {
+		if child, ok := n.children[seg]; ok {
+			if result, params, ok := matchNode(child, rest); ok {
+				return result, params, true
+			}
+		}
+	}
+
+	if n.param != nil {
+		if result, params, ok := matchNode(n.param, rest); ok {
+			// A deeper param of the same name (e.g. a nested resource's
+			// own :id) is more specific and was already set on the way
+			// back up from it; don't let an outer one clobber it.
+			if _, exists := params[n.param.segment]; !exists {
+				params[n.param.segment] = seg
+			}
+			// Aliases are scoped to the next segment the request actually
+			// continues into, so a request that stops at this node (the
+			// parent resource's own route) never picks up a nested
+			// resource's alias for it.
+			if len(rest) > 0 {
+				for _, alias := range n.param.childAliases[rest[0]] {
+					if _, exists := params[alias]; !exists {
+						params[alias] = seg
+					}
+				}
+			}
+			return result, params, true
+		}
+	}
+
+	if n.wildcard != nil && n.wildcard.registered() {
+		params := RouteParams{n.wildcard.segment: strings.Join(segments, "/")}
+		return n.wildcard, params, true
+	}
+
+	return nil, nil, false
+}
+
+// handler returns the handler that should serve method at n, preferring a
+// method-specific route and falling back to a method-agnostic one. ok is
+// false if neither exists for method; allow lists the methods that do, for
+// building a 405 response.
+func (n *routeNode) handler(method string) (h http.Handler, ok bool) {
+	if h, ok = n.routes[method]; ok {
+		return h, true
+	}
+	if n.any != nil {
+		return n.any, true
+	}
+	return nil, false
+}
+
+// registered returns true if n has any routes or method-agnostic handler
+// registered on it at all.
+func (n *routeNode) registered() bool {
+	return len(n.routes) > 0 || n.any != nil
+}
+
+// allow returns the sorted list of methods registered on n, suitable for
+// an Allow header.
+func (n *routeNode) allow() string {
+	methods := make([]string, 0, len(n.routes))
+	for m := range n.routes {
+		methods = append(methods, m)
+	}
+	sort.Strings(methods)
+	return strings.Join(methods, ", ")
+}
+
+// CleanPath returns the canonical form of p: multiple slashes collapsed,
+// and "." and ".." path elements resolved, as path.Clean does, but always
+// rooted at "/" and with a trailing slash preserved if p had one. It is
+// used to normalize request paths before matching them against a tree, in
+// the spirit of httprouter's CleanPath.
+func CleanPath(p string) string {
+	if p == "" {
+		return "/"
+	}
+	if p[0] != '/' {
+		p = "/" + p
+	}
+
+	np := path.Clean(p)
+	if p[len(p)-1] == '/' && np != "/" {
+		np += "/"
+	}
+	return np
+}
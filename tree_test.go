@@ -0,0 +1,112 @@
+package resource
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestCleanPath(t *testing.T) {
+	testcases := []struct {
+		Path     string
+		Expected string
+	}{
+		{"", "/"},
+		{"/", "/"},
+		{"/posts", "/posts"},
+		{"posts", "/posts"},
+		{"//posts", "/posts"},
+		{"/posts/", "/posts/"},
+		{"/posts/./1", "/posts/1"},
+		{"/posts/foo/../1", "/posts/1"},
+		{"/posts/../../1", "/1"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Path, func(t *testing.T) {
+			if got := CleanPath(tc.Path); got != tc.Expected {
+				t.Errorf("CleanPath(%q) = %q, expected %q", tc.Path, got, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestTreeMethodNotAllowed(t *testing.T) {
+	tr := newTree()
+	tr.insert("GET", "/posts/:id", http.HandlerFunc(testHandler))
+	tr.insert("PUT", "/posts/:id", http.HandlerFunc(testHandler))
+
+	n, _ := tr.match("/posts/1")
+	if n == nil || !n.registered() {
+		t.Fatal("expected node to be registered")
+	}
+
+	if _, ok := n.handler("DELETE"); ok {
+		t.Fatal("expected DELETE to be unregistered")
+	}
+
+	allow := n.allow()
+	if allow != "GET, PUT" {
+		t.Errorf("allow = %q, expected %q", allow, "GET, PUT")
+	}
+}
+
+func TestTreeNotFound(t *testing.T) {
+	tr := newTree()
+	tr.insert("GET", "/posts/:id", http.HandlerFunc(testHandler))
+
+	n, _ := tr.match("/comments/1")
+	if n != nil {
+		t.Fatal("expected no match")
+	}
+}
+
+// TestTreeBacktracksDeadEndStaticBranch covers a static branch that's only
+// a pass-through to a deeper route (no route of its own): matching a
+// shorter path under it must fall back to the param sibling rather than
+// failing just because the static branch was tried first.
+func TestTreeBacktracksDeadEndStaticBranch(t *testing.T) {
+	tr := newTree()
+	tr.insert("GET", "/users/admin/settings", http.HandlerFunc(testHandler))
+	tr.insert("GET", "/users/:id", http.HandlerFunc(testHandler))
+
+	n, params := tr.match("/users/admin")
+	if n == nil || !n.registered() {
+		t.Fatal("expected /users/admin to match /users/:id")
+	}
+	if params["id"] != "admin" {
+		t.Errorf("id = %q, expected %q", params["id"], "admin")
+	}
+
+	n, _ = tr.match("/users/admin/settings")
+	if n == nil || !n.registered() {
+		t.Fatal("expected /users/admin/settings to match its own route")
+	}
+}
+
+// TestTreeWildcard covers a trailing *wildcard segment, which must capture
+// the rest of the path (including further slashes) as a single param.
+func TestTreeWildcard(t *testing.T) {
+	tr := newTree()
+	tr.insert("GET", "/files/*path", http.HandlerFunc(testHandler))
+
+	n, params := tr.match("/files/a/b/c")
+	if n == nil || !n.registered() {
+		t.Fatal("expected /files/a/b/c to match /files/*path")
+	}
+	if params["path"] != "a/b/c" {
+		t.Errorf("path = %q, expected %q", params["path"], "a/b/c")
+	}
+
+	n, params = tr.match("/files/a")
+	if n == nil || !n.registered() {
+		t.Fatal("expected /files/a to match /files/*path")
+	}
+	if params["path"] != "a" {
+		t.Errorf("path = %q, expected %q", params["path"], "a")
+	}
+
+	n, _ = tr.match("/files")
+	if n != nil {
+		t.Error("expected /files alone, with nothing to capture, not to match")
+	}
+}